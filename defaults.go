@@ -0,0 +1,67 @@
+package konfig
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// applyDefaults walks a freshly zeroed struct and assigns the parsed `,default=` tag
+// value to every field that still has its zero value. It mirrors the traversal done by
+// getStructKeys/exportStruct/validateStruct so the same tag is honoured consistently.
+func applyDefaults(v reflect.Value, opts *decoderOptions) {
+	var t = v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		var fieldValue = t.Field(i)
+		var field = v.Field(i)
+
+		if fieldValue.PkgPath != "" || !field.CanSet() {
+			continue
+		}
+
+		var ft = parseFieldTag(fieldValue.Tag.Get(opts.tagName))
+		if ft.name == "-" {
+			continue
+		}
+
+		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+			if !field.IsNil() {
+				applyDefaults(field.Elem(), opts)
+			}
+			continue
+		}
+
+		if field.Kind() == reflect.Struct {
+			applyDefaults(field, opts)
+			continue
+		}
+
+		if ft.hasDefault && field.IsZero() {
+			setDefault(field, ft.def)
+		}
+	}
+}
+
+// setDefault assigns the string form of a `,default=` tag value to field, converting it
+// via spf13/cast the same way castValue does for config values. Only string, bool,
+// numeric and time.Duration fields are set; other kinds (slices, maps, structs, ...) are
+// left untouched.
+func setDefault(field reflect.Value, def string) {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		field.SetInt(int64(cast.ToDuration(def)))
+		return
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(def)
+	case reflect.Bool:
+		field.SetBool(cast.ToBool(def))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(cast.ToInt64(def))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(cast.ToUint64(def))
+	case reflect.Float32, reflect.Float64:
+		field.SetFloat(cast.ToFloat64(def))
+	}
+}
@@ -0,0 +1,137 @@
+package konfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Export walks the value bound to the root config store and returns its contents as a
+// flat dotted key/value map, using the same tag/squash rules as Bind. See (*S).Export.
+func Export() map[string]interface{} {
+	return instance().Export()
+}
+
+// Sync diffs the value bound to the root config store against the snapshot taken at the
+// last Bind or Sync call and returns the keys that were added or changed. See (*S).Sync.
+func Sync() map[string]interface{} {
+	return instance().Sync()
+}
+
+// Export walks the value currently bound to the config store and returns its contents
+// as a flat map keyed the same way config keys are, honouring the tag name configured
+// via WithTagName and the squash/omitempty field options. For a map[string]interface{}
+// binding it is simply a copy of the bound map.
+func (c *S) Export() map[string]interface{} {
+	return c.v.export()
+}
+
+// Sync diffs the value currently bound to the config store against the snapshot taken
+// at the last Bind or Sync call and returns the keys that were added or whose value
+// changed, in the same dotted format as Export. Keys present in the snapshot but absent
+// from the current value are not reported. It inverts the usual config-store -> bound-value flow: callers can
+// feed the result to a file or env loader to persist mutations made directly on the
+// bound struct, such as generated defaults or CLI overrides.
+func (c *S) Sync() map[string]interface{} {
+	return c.v.sync()
+}
+
+func (val *value) export() map[string]interface{} {
+	var out = make(map[string]interface{})
+
+	var configValue = val.v.Load()
+
+	if val.isMap {
+		for kk, vv := range configValue.(map[string]interface{}) {
+			out[kk] = vv
+		}
+		return out
+	}
+
+	exportStruct(reflect.ValueOf(configValue), "", val.decOpts, out)
+	return out
+}
+
+func (val *value) sync() map[string]interface{} {
+	val.mut.Lock()
+	defer val.mut.Unlock()
+
+	var current = val.export()
+	var diff = make(map[string]interface{})
+
+	for kk, vv := range current {
+		if ov, ok := val.shadow[kk]; !ok || !reflect.DeepEqual(ov, vv) {
+			diff[kk] = vv
+		}
+	}
+
+	val.shadow = current
+	return diff
+}
+
+func exportStruct(v reflect.Value, prefix string, opts *decoderOptions, out map[string]interface{}) {
+	var t = v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		var fieldValue = t.Field(i)
+		var field = v.Field(i)
+
+		// unexported fields can't be read via reflection; field.Interface() would panic
+		if fieldValue.PkgPath != "" {
+			continue
+		}
+
+		var ft = parseFieldTag(fieldValue.Tag.Get(opts.tagName))
+
+		if ft.name == "-" {
+			continue
+		}
+
+		// use the name mapper when tag is not specified
+		if ft.name == "" && !ft.squash {
+			if fieldValue.Anonymous {
+				ft.squash = true
+			} else {
+				ft.name = opts.nameMapper(fieldValue.Name)
+			}
+		}
+
+		if ft.omitempty && field.IsZero() {
+			continue
+		}
+
+		var key string
+		if ft.squash {
+			key = prefix
+		} else if prefix == "" {
+			key = ft.name
+		} else {
+			key = prefix + KeySep + ft.name
+		}
+
+		exportValue(field, key, opts, out)
+	}
+}
+
+func exportValue(field reflect.Value, key string, opts *decoderOptions, out map[string]interface{}) {
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() {
+			return
+		}
+		exportValue(field.Elem(), key, opts, out)
+	case reflect.Struct:
+		exportStruct(field, key, opts, out)
+	case reflect.Map:
+		for _, mk := range field.MapKeys() {
+			exportValue(field.MapIndex(mk), key+KeySep+fmt.Sprint(mk.Interface()), opts, out)
+		}
+	case reflect.Slice:
+		for i := 0; i < field.Len(); i++ {
+			exportValue(field.Index(i), key+KeySep+strconv.Itoa(i), opts, out)
+		}
+	default:
+		if field.CanInterface() {
+			out[key] = field.Interface()
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package konfig
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NameMapper derives a config key from a struct field's Go name when the field carries
+// no explicit name in its konfig tag (or whichever tag WithTagName configures).
+type NameMapper func(string) string
+
+// LowerCase is the default NameMapper: it lowercases the field name as-is, e.g.
+// "MaxRetryCount" becomes "maxretrycount".
+func LowerCase(name string) string {
+	return strings.ToLower(name)
+}
+
+// SnakeCase maps a field name to snake_case, e.g. "MaxRetryCount" becomes
+// "max_retry_count".
+func SnakeCase(name string) string {
+	return strings.ToLower(splitWords(name, "_"))
+}
+
+// ScreamingSnakeCase maps a field name to SCREAMING_SNAKE_CASE, e.g. "MaxRetryCount"
+// becomes "MAX_RETRY_COUNT".
+func ScreamingSnakeCase(name string) string {
+	return strings.ToUpper(splitWords(name, "_"))
+}
+
+// KebabCase maps a field name to kebab-case, e.g. "MaxRetryCount" becomes
+// "max-retry-count".
+func KebabCase(name string) string {
+	return strings.ToLower(splitWords(name, "-"))
+}
+
+// splitWords inserts sep between the words of a Go identifier, treating a run of
+// uppercase letters followed by a lowercase one as starting a new word, so "HTTPCode"
+// splits into "HTTP" and "Code" rather than one letter per word.
+func splitWords(name string, sep string) string {
+	var runes = []rune(name)
+	var out strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			var prevIsLower = unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			var nextIsLower = i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevIsLower || (nextIsLower && unicode.IsUpper(runes[i-1])) {
+				out.WriteString(sep)
+			}
+		}
+		out.WriteRune(r)
+	}
+
+	return out.String()
+}
@@ -0,0 +1,112 @@
+package konfig
+
+import "strings"
+
+// DecoderOption configures how config values are matched against the fields of a value
+// bound with Bind or BindStructStrict.
+type DecoderOption func(*decoderOptions)
+
+// decoderOptions holds the resolved set of DecoderOption applied to a bound value.
+type decoderOptions struct {
+	tagName          string
+	weaklyTypedInput bool
+	errorUnused      bool
+	nameMapper       NameMapper
+}
+
+func newDecoderOptions(opts ...DecoderOption) *decoderOptions {
+	var o = &decoderOptions{
+		tagName:          TagKey,
+		weaklyTypedInput: true,
+		nameMapper:       LowerCase,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithTagName overrides the struct tag ("konfig" by default) used to map config keys to
+// struct fields.
+func WithTagName(tagName string) DecoderOption {
+	return func(o *decoderOptions) {
+		o.tagName = tagName
+	}
+}
+
+// WithWeaklyTypedInput controls whether config values are coerced into the destination
+// field's type via spf13/cast (the default). When disabled, a type mismatch is recorded
+// as a bind error instead of being silently converted; see BindErrors.
+func WithWeaklyTypedInput(weaklyTypedInput bool) DecoderOption {
+	return func(o *decoderOptions) {
+		o.weaklyTypedInput = weaklyTypedInput
+	}
+}
+
+// WithErrorUnused makes a config key that does not map to any field of the bound value
+// a bind error, retrievable with BindErrors, instead of only being logged.
+func WithErrorUnused(errorUnused bool) DecoderOption {
+	return func(o *decoderOptions) {
+		o.errorUnused = errorUnused
+	}
+}
+
+// WithNameMapper overrides how a struct field's Go name is turned into a config key
+// when the field carries no explicit name in its tag. The default is LowerCase; see
+// also SnakeCase, ScreamingSnakeCase and KebabCase.
+func WithNameMapper(mapper NameMapper) DecoderOption {
+	return func(o *decoderOptions) {
+		o.nameMapper = mapper
+	}
+}
+
+// fieldTag is the parsed form of a struct tag such as
+// `konfig:"name,squash,omitempty,required,default=1,min=0,max=10,oneof=a|b"`.
+type fieldTag struct {
+	name       string
+	squash     bool
+	omitempty  bool
+	required   bool
+	hasDefault bool
+	def        string
+	hasMin     bool
+	min        string
+	hasMax     bool
+	max        string
+	oneOf      []string
+}
+
+// parseFieldTag splits a raw struct tag value into its name and its comma-separated
+// options, following the convention used by encoding/json, mapstructure and copier.
+// squash/embed and omitempty are plain flags; required is a validation flag; default,
+// min, max and oneof take a value via "option=value" (oneof's value is "|"-separated).
+func parseFieldTag(raw string) fieldTag {
+	var parts = strings.Split(raw, ",")
+	var ft = fieldTag{name: parts[0]}
+	for _, opt := range parts[1:] {
+		var key, value = opt, ""
+		if idx := strings.Index(opt, "="); idx >= 0 {
+			key, value = opt[:idx], opt[idx+1:]
+		}
+		switch key {
+		case "squash", "embed":
+			ft.squash = true
+		case "omitempty":
+			ft.omitempty = true
+		case "required":
+			ft.required = true
+		case "default":
+			ft.hasDefault = true
+			ft.def = value
+		case "min":
+			ft.hasMin = true
+			ft.min = value
+		case "max":
+			ft.hasMax = true
+			ft.max = value
+		case "oneof":
+			ft.oneOf = strings.Split(value, "|")
+		}
+	}
+	return ft
+}
@@ -0,0 +1,159 @@
+package konfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// Validate walks the value bound to the root config store and reports every field that
+// fails its `,required`, `,min=`, `,max=` or `,oneof=` tag constraint. See (*S).Validate.
+func Validate() error {
+	return instance().Validate()
+}
+
+// MustValidate calls Validate and panics if it returns an error. See (*S).MustValidate.
+func MustValidate() {
+	instance().MustValidate()
+}
+
+// Validate walks the value currently bound to the config store and reports every field
+// that fails its `,required`, `,min=`, `,max=` or `,oneof=` tag constraint as a single
+// combined error, or nil if the bound value satisfies all of them. A field carrying
+// `,default=` is assigned that value by Bind whenever it is still zero, so it is also
+// exempt from the `,required` check here. Validate is a no-op for a
+// map[string]interface{} binding; call it after Bind and after any Set/SetValues that
+// may have touched a bound struct.
+func (c *S) Validate() error {
+	if c.v.isMap {
+		return nil
+	}
+
+	var errs []error
+	validateStruct(reflect.ValueOf(c.v.v.Load()), "", c.v.decOpts, &errs)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	var msgs = make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Errorf("konfig: validation failed: %s", strings.Join(msgs, "; "))
+}
+
+// MustValidate calls Validate and panics if it returns an error.
+func (c *S) MustValidate() {
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
+}
+
+func validateStruct(v reflect.Value, prefix string, opts *decoderOptions, errs *[]error) {
+	var t = v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		var fieldValue = t.Field(i)
+		var field = v.Field(i)
+		var ft = parseFieldTag(fieldValue.Tag.Get(opts.tagName))
+
+		if ft.name == "-" {
+			continue
+		}
+
+		// use the name mapper when tag is not specified
+		if ft.name == "" && !ft.squash {
+			if fieldValue.Anonymous {
+				ft.squash = true
+			} else {
+				ft.name = opts.nameMapper(fieldValue.Name)
+			}
+		}
+
+		var key string
+		if ft.squash {
+			key = prefix
+		} else if prefix == "" {
+			key = ft.name
+		} else {
+			key = prefix + KeySep + ft.name
+		}
+
+		if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct {
+			if !field.IsNil() {
+				validateStruct(field.Elem(), key, opts, errs)
+			} else if ft.required {
+				*errs = append(*errs, fmt.Errorf("%s is required", key))
+			}
+			continue
+		}
+
+		if field.Kind() == reflect.Struct {
+			validateStruct(field, key, opts, errs)
+			continue
+		}
+
+		var isZero = field.IsZero()
+
+		if ft.required && isZero && !ft.hasDefault {
+			*errs = append(*errs, fmt.Errorf("%s is required", key))
+			continue
+		}
+
+		if isZero {
+			continue
+		}
+
+		if ft.hasMin || ft.hasMax {
+			validateRange(key, field, ft, errs)
+		}
+
+		if len(ft.oneOf) > 0 {
+			var strVal = cast.ToString(field.Interface())
+			var found bool
+			for _, allowed := range ft.oneOf {
+				if strVal == allowed {
+					found = true
+					break
+				}
+			}
+			if !found {
+				*errs = append(*errs, fmt.Errorf("%s must be one of %q, got %q", key, strings.Join(ft.oneOf, "|"), strVal))
+			}
+		}
+	}
+}
+
+// validateRange checks a field against its `,min=`/`,max=` tag options: a length bound
+// for strings, slices, arrays and maps, a numeric bound for everything else.
+func validateRange(key string, field reflect.Value, ft fieldTag, errs *[]error) {
+	switch field.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		var length = field.Len()
+		if ft.hasMin {
+			if min, err := strconv.Atoi(ft.min); err == nil && length < min {
+				*errs = append(*errs, fmt.Errorf("%s has length %d, want at least %d", key, length, min))
+			}
+		}
+		if ft.hasMax {
+			if max, err := strconv.Atoi(ft.max); err == nil && length > max {
+				*errs = append(*errs, fmt.Errorf("%s has length %d, want at most %d", key, length, max))
+			}
+		}
+	default:
+		var n = cast.ToFloat64(field.Interface())
+		if ft.hasMin {
+			if min, err := strconv.ParseFloat(ft.min, 64); err == nil && n < min {
+				*errs = append(*errs, fmt.Errorf("%s is %v, want at least %v", key, n, min))
+			}
+		}
+		if ft.hasMax {
+			if max, err := strconv.ParseFloat(ft.max, 64); err == nil && n > max {
+				*errs = append(*errs, fmt.Errorf("%s is %v, want at most %v", key, n, max))
+			}
+		}
+	}
+}
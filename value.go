@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -30,11 +31,119 @@ var (
 )
 
 type value struct {
-	s     *S
-	v     *atomic.Value
-	vt    reflect.Type
-	mut   *sync.Mutex
-	isMap bool
+	s          *S
+	v          *atomic.Value
+	vt         reflect.Type
+	mut        *sync.Mutex
+	isMap      bool
+	decOpts    *decoderOptions
+	errs       []error
+	convMut    *sync.RWMutex
+	converters map[converterKey]converterFunc
+	shadow     map[string]interface{}
+}
+
+// converterKey identifies a RegisterConverter registration by the concrete type of the
+// config value being decoded and the type of the struct field receiving it.
+type converterKey struct {
+	src reflect.Type
+	dst reflect.Type
+}
+
+// converterFunc converts a raw config value into the type expected by a bound struct
+// field, as registered with RegisterConverter.
+type converterFunc func(interface{}) (interface{}, error)
+
+// registerConverter records fn as the converter to use whenever a value of type src is
+// set on a struct field of type dst. Converters survive across rebinds of the config
+// store, since callers typically register them once at startup.
+func (val *value) registerConverter(src, dst reflect.Type, fn converterFunc) {
+	val.convMut.Lock()
+	defer val.convMut.Unlock()
+	if val.converters == nil {
+		val.converters = make(map[converterKey]converterFunc)
+	}
+	val.converters[converterKey{src: src, dst: dst}] = fn
+}
+
+func (val *value) converter(src, dst reflect.Type) (converterFunc, bool) {
+	val.convMut.RLock()
+	defer val.convMut.RUnlock()
+	fn, ok := val.converters[converterKey{src: src, dst: dst}]
+	return fn, ok
+}
+
+// pendingConverter is a RegisterConverter call made before the first Bind on an *S, held
+// until there is a *value to store it on.
+type pendingConverter struct {
+	src, dst reflect.Type
+	fn       converterFunc
+}
+
+var (
+	pendingConvertersMut sync.Mutex
+	pendingConverters    = map[*S][]pendingConverter{}
+)
+
+// drainPendingConverters registers on val every converter that was recorded for c before
+// its *value existed, and forgets them.
+func drainPendingConverters(c *S, val *value) {
+	pendingConvertersMut.Lock()
+	var pending = pendingConverters[c]
+	delete(pendingConverters, c)
+	pendingConvertersMut.Unlock()
+
+	for _, p := range pending {
+		val.registerConverter(p.src, p.dst, p.fn)
+	}
+}
+
+// assign sets field to v, giving priority to any converter registered for the
+// (source type, field type) pair via RegisterConverter, then falling back to
+// encoding.TextUnmarshaler, and finally to spf13/cast.
+func (val *value) assign(field reflect.Value, k string, v interface{}) {
+	if v != nil {
+		if fn, ok := val.converter(reflect.TypeOf(v), field.Type()); ok {
+			result, err := fn(v)
+			if err != nil {
+				val.addErr(fmt.Errorf("konfig: key %q: %w", k, err))
+				return
+			}
+			if result == nil {
+				field.Set(reflect.Zero(field.Type()))
+			} else {
+				field.Set(reflect.ValueOf(result))
+			}
+			return
+		}
+	}
+
+	if val.unmarshal(field, v) {
+		return
+	}
+
+	result, err := val.castValue(field.Interface(), v)
+	if err != nil {
+		val.addErr(fmt.Errorf("konfig: key %q: %w", k, err))
+	} else if field.CanAddr() && result == nil {
+		field.Set(reflect.Zero(field.Type()))
+	} else {
+		field.Set(reflect.ValueOf(result))
+	}
+}
+
+// addErr records a bind error against the currently bound value. Callers must hold
+// val.mut, which set() and setValues() already do for the whole of a bind operation.
+func (val *value) addErr(err error) {
+	val.errs = append(val.errs, err)
+}
+
+// errors returns the errors accumulated while applying config values to the bound
+// value since the last call to Bind or BindStructStrict.
+func (val *value) errors() []error {
+	val.mut.Lock()
+	defer val.mut.Unlock()
+	return val.errs
 }
 
 func sorted(source map[string]interface{}) (result []string) {
@@ -51,13 +160,26 @@ func Value() interface{} {
 }
 
 // Bind binds a value to the root config store
-func Bind(v interface{}) {
-	instance().Bind(v)
+func Bind(v interface{}, opts ...DecoderOption) {
+	instance().Bind(v, opts...)
 }
 
 // BindStructStrict binds a value to the root config store and adds the exposed keys as strict keys
-func BindStructStrict(v interface{}) {
-	instance().BindStructStrict(v)
+func BindStructStrict(v interface{}, opts ...DecoderOption) {
+	instance().BindStructStrict(v, opts...)
+}
+
+// BindErrors returns the errors accumulated while applying config values to the value
+// bound to the root config store. See (*S).BindErrors.
+func BindErrors() []error {
+	return instance().BindErrors()
+}
+
+// RegisterConverter registers fn as the converter used whenever a config value of type
+// srcType is set on a bound struct field of type dstType, on the root config store. See
+// (*S).RegisterConverter.
+func RegisterConverter(srcType, dstType reflect.Type, fn func(interface{}) (interface{}, error)) {
+	instance().RegisterConverter(srcType, dstType, fn)
 }
 
 // Value returns the value bound to the config store
@@ -67,7 +189,8 @@ func (c *S) Value() interface{} {
 
 // Bind binds a value (either a map[string]interface{} or a struct) to the config store.
 // When config values are set on the config store, they are also set on the bound value.
-func (c *S) Bind(v interface{}) {
+// Options control how config keys are matched against struct fields; see DecoderOption.
+func (c *S) Bind(v interface{}, opts ...DecoderOption) {
 	var t = reflect.TypeOf(v)
 	var k = t.Kind()
 	//  if it is neither a map nor a struct
@@ -81,25 +204,45 @@ func (c *S) Bind(v interface{}) {
 	}
 
 	var val = &value{
-		s:     c,
-		isMap: k == reflect.Map,
-		mut:   &sync.Mutex{},
+		s:       c,
+		isMap:   k == reflect.Map,
+		mut:     &sync.Mutex{},
+		convMut: &sync.RWMutex{},
+		decOpts: newDecoderOptions(opts...),
 	}
 
+	// converters are registered once per application and must survive a rebind
+	if c.v != nil {
+		val.converters = c.v.converters
+	}
+	drainPendingConverters(c, val)
+
 	val.vt = t
 
-	// create a new pointer to the given value and store it
+	// create a new pointer to the given value and store it, applying any `,default=`
+	// tag values to the fields that carry one before it is ever observed
 	var atomicValue atomic.Value
-	var n = reflect.Zero(val.vt)
+	var n reflect.Value
+	if val.isMap {
+		n = reflect.Zero(val.vt)
+	} else {
+		var ptr = reflect.New(val.vt)
+		applyDefaults(ptr.Elem(), val.decOpts)
+		n = ptr.Elem()
+	}
 	atomicValue.Store(n.Interface())
 
 	val.v = &atomicValue
 
 	c.v = val
+
+	// snapshot the freshly bound value (zero apart from `,default=` fields) so the first Sync only reports
+	// mutations made after this Bind call
+	val.shadow = val.export()
 }
 
 // BindStructStrict binds a value (must a struct) to the config store and adds the exposed fields as strick keys.
-func (c *S) BindStructStrict(v interface{}) {
+func (c *S) BindStructStrict(v interface{}, opts ...DecoderOption) {
 	var t = reflect.TypeOf(v)
 	var k = t.Kind()
 	//  if it not a struct
@@ -107,45 +250,74 @@ func (c *S) BindStructStrict(v interface{}) {
 		panic(ErrIncorrectStructValue)
 	}
 
-	keys := getStructKeys(t, "")
+	var decOpts = newDecoderOptions(opts...)
+
+	keys := getStructKeys(t, "", decOpts)
 	c.Strict(keys...)
-	c.Bind(v)
+	c.Bind(v, opts...)
+}
+
+// BindErrors returns the errors accumulated while applying config values to the bound
+// value since the last call to Bind or BindStructStrict. With the default options a
+// mismatched key is logged and skipped rather than recorded here; WithErrorUnused and
+// a disabled WithWeaklyTypedInput both feed this list instead.
+func (c *S) BindErrors() []error {
+	return c.v.errors()
+}
+
+// RegisterConverter registers fn as the converter used whenever a config value of type
+// srcType is set on a bound struct field of type dstType, overriding the default
+// spf13/cast conversions and encoding.TextUnmarshaler support. This allows binding
+// fields of types konfig has no built-in knowledge of, such as url.URL, regexp.Regexp
+// or a project-specific enum, without requiring them to implement TextUnmarshaler.
+// Converters are registered once and kept across rebinds of the config store.
+// RegisterConverter may be called before the first Bind on c (the common "register a
+// decode hook once at startup" usage) — the converter is then queued and applied as
+// soon as Bind creates the bound value.
+func (c *S) RegisterConverter(srcType, dstType reflect.Type, fn func(interface{}) (interface{}, error)) {
+	if c.v == nil {
+		pendingConvertersMut.Lock()
+		pendingConverters[c] = append(pendingConverters[c], pendingConverter{src: srcType, dst: dstType, fn: fn})
+		pendingConvertersMut.Unlock()
+		return
+	}
+	c.v.registerConverter(srcType, dstType, fn)
 }
 
-func getStructKeys(t reflect.Type, prefix string) []string {
+func getStructKeys(t reflect.Type, prefix string, opts *decoderOptions) []string {
 	var keys []string
 	for i := 0; i < t.NumField(); i++ {
 		var fieldValue = t.Field(i)
-		var tag = fieldValue.Tag.Get(TagKey)
+		var ft = parseFieldTag(fieldValue.Tag.Get(opts.tagName))
 
-		if tag == "-" {
+		if ft.name == "-" {
 			continue
 		}
 
-		// use field name when konfig tag is not specified
-		if tag == "" {
-			if fieldValue.Name == "" {
-				tag = ",embed"
+		// use the name mapper when tag is not specified
+		if ft.name == "" && !ft.squash {
+			if fieldValue.Anonymous {
+				ft.squash = true
 			} else {
-				tag = strings.ToLower(fieldValue.Name)
+				ft.name = opts.nameMapper(fieldValue.Name)
 			}
 		}
 
 		if fieldValue.Type.Kind() == reflect.Struct {
 			var prefix string
-			if tag == ",embed" {
+			if ft.squash {
 				prefix = ""
 			} else {
-				prefix = tag + KeySep
+				prefix = ft.name + KeySep
 			}
-			structKeys := getStructKeys(fieldValue.Type, prefix)
+			structKeys := getStructKeys(fieldValue.Type, prefix, opts)
 			keys = append(keys, structKeys...)
 
 			// don't add the parent tag
 			continue
 		}
 
-		keys = append(keys, prefix+tag)
+		keys = append(keys, prefix+ft.name)
 	}
 
 	return keys
@@ -231,41 +403,38 @@ func (val *value) setStruct(k string, v interface{}, targetValue reflect.Value)
 	for i := 0; i < valType.NumField(); i++ {
 		var fieldValue = valType.Field(i)
 		var fieldName = fieldValue.Name
-		var tag = fieldValue.Tag.Get(TagKey)
+		var ft = parseFieldTag(fieldValue.Tag.Get(val.decOpts.tagName))
 
-		// use field name when konfig tag is not specified
-		if tag == "" && fieldValue.Name == "" {
-			tag = ",embed"
+		// use the name mapper when tag is not specified
+		if ft.name == "" && !ft.squash {
+			if fieldValue.Anonymous {
+				ft.squash = true
+			} else {
+				ft.name = val.decOpts.nameMapper(fieldValue.Name)
+			}
 		}
 
 		// check tag, if it matches key
 		// assign v to field
-		if tag == k || strings.EqualFold(fieldName, k) {
+		if ft.name == k || strings.EqualFold(fieldName, k) {
 			var field = valValue.FieldByName(fieldValue.Name)
 			if field.CanSet() {
-				if !unmarshal(field, v) {
-					result := castValue(field.Interface(), v)
-					if field.CanAddr() && result == nil {
-						field.Set(reflect.Zero(field.Type()))
-					} else {
-						field.Set(reflect.ValueOf(result))
-					}
-				}
+				val.assign(field, k, v)
 			}
 			set = true
 			continue
 
 			// else if key has tag in prefix
-		} else if tag == ",embed" ||
-			strings.HasPrefix(k, tag+KeySep) ||
+		} else if ft.squash ||
+			strings.HasPrefix(k, ft.name+KeySep) ||
 			strings.HasPrefix(strings.ToLower(k), strings.ToLower(fieldName)+KeySep) {
 
 			var nK string
 
-			if tag == ",embed" {
+			if ft.squash {
 				nK = k
-			} else if strings.HasPrefix(k, tag+KeySep) {
-				nK = k[len(tag+KeySep):]
+			} else if strings.HasPrefix(k, ft.name+KeySep) {
+				nK = k[len(ft.name+KeySep):]
 			} else {
 				nK = k[len(fieldName+KeySep):]
 			}
@@ -379,25 +548,94 @@ func (val *value) setStruct(k string, v interface{}, targetValue reflect.Value)
 						continue
 					}
 				}
+			// Is a slice, keyed by index: parent.0.field for []struct/[]*struct,
+			// parent.0 for scalar slices ([]string, []int, []float64, ...).
+			case reflect.Slice:
+				var field = valValue.FieldByName(fieldValue.Name)
+				if !field.CanSet() {
+					continue
+				}
+
+				var keyElt = strings.SplitN(nK, KeySep, 2)
+				var idx, idxErr = strconv.Atoi(keyElt[0])
+				if idxErr != nil {
+					continue
+				}
+
+				// grow the slice as needed, preserving the elements already set
+				if field.IsNil() || field.Len() <= idx {
+					var grown = reflect.MakeSlice(fieldValue.Type, idx+1, idx+1)
+					reflect.Copy(grown, field)
+					field.Set(grown)
+				}
+
+				var elemType = fieldValue.Type.Elem()
+				var elemIsPtrStruct = elemType.Kind() == reflect.Ptr && elemType.Elem().Kind() == reflect.Struct
+				var elemIsStruct = elemType.Kind() == reflect.Struct
+
+				if elemIsStruct || elemIsPtrStruct {
+					if len(keyElt) != 2 {
+						continue
+					}
+
+					var structType = elemType
+					if elemIsPtrStruct {
+						structType = elemType.Elem()
+					}
+
+					var nVal = reflect.New(structType)
+					var elt = field.Index(idx)
+
+					// we copy the old value, to make sure we don't lose anything
+					if elemIsPtrStruct {
+						if !elt.IsNil() {
+							copier.Copy(nVal.Interface(), elt.Interface())
+						}
+					} else {
+						copier.Copy(nVal.Interface(), elt.Interface())
+					}
+
+					if ok := val.setStruct(keyElt[1], v, nVal); ok {
+						if elemIsPtrStruct {
+							elt.Set(nVal)
+						} else {
+							elt.Set(nVal.Elem())
+						}
+						set = true
+					}
+					continue
+				}
+
+				// scalar element: parent.<idx> = v
+				var elt = field.Index(idx)
+				if elt.CanSet() {
+					val.assign(elt, k, v)
+					set = true
+				}
+				continue
 			}
 		}
 	}
 
 	if !set {
-		val.s.cfg.Logger.Get().Debug(
-			fmt.Sprintf(
-				"Config key %s not found in bound value",
-				k,
-			),
-		)
+		if val.decOpts.errorUnused {
+			val.addErr(fmt.Errorf("konfig: config key %q does not match any field of %s", k, valType))
+		} else {
+			val.s.cfg.Logger.Get().Debug(
+				fmt.Sprintf(
+					"Config key %s not found in bound value",
+					k,
+				),
+			)
+		}
 	}
 
 	return set
 }
 
-func unmarshalText(f reflect.Value, v interface{}) bool {
+func unmarshalText(f reflect.Value, v interface{}) (bool, error) {
 	if !f.Type().Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
-		return false
+		return false, nil
 	}
 	if tu, ok := f.Interface().(encoding.TextUnmarshaler); ok {
 		if f.Type().Kind() == reflect.Ptr && f.IsNil() {
@@ -408,128 +646,170 @@ func unmarshalText(f reflect.Value, v interface{}) bool {
 		str := cast.ToString(v)
 		err := tu.UnmarshalText([]byte(str))
 		if err != nil {
-			panic(err)
+			return false, err
 		}
-		return err == nil
+		return true, nil
 	}
-	return false
+	return false, nil
 }
 
-func unmarshal(f reflect.Value, v interface{}) bool {
-	if unmarshalText(f, v) {
-		return true
-	} else if f.CanAddr() && unmarshalText(f.Addr(), v) {
+// unmarshal tries to decode v into f via encoding.TextUnmarshaler. It returns true if f
+// implements the interface, regardless of whether decoding succeeded; a failure is
+// recorded on val so it surfaces through BindErrors instead of panicking.
+func (val *value) unmarshal(f reflect.Value, v interface{}) bool {
+	if ok, err := unmarshalText(f, v); ok || err != nil {
+		if err != nil {
+			val.addErr(fmt.Errorf("konfig: unmarshal text: %w", err))
+		}
 		return true
-	} else {
-		return false
 	}
+	if f.CanAddr() {
+		if ok, err := unmarshalText(f.Addr(), v); ok || err != nil {
+			if err != nil {
+				val.addErr(fmt.Errorf("konfig: unmarshal text: %w", err))
+			}
+			return true
+		}
+	}
+	return false
 }
 
-func castValue(f interface{}, v interface{}) interface{} {
+func (val *value) castValue(f interface{}, v interface{}) (interface{}, error) {
+	if !val.decOpts.weaklyTypedInput {
+		if err := checkAssignable(f, v); err != nil {
+			return nil, err
+		}
+	}
 	switch f.(type) {
 	// string
 	case *string:
 		value := cast.ToString(v)
-		return &value
+		return &value, nil
 	case string:
-		return cast.ToString(v)
+		return cast.ToString(v), nil
 	// bool
 	case *bool:
 		value := cast.ToBool(v)
-		return &value
+		return &value, nil
 	case bool:
-		return cast.ToBool(v)
+		return cast.ToBool(v), nil
 	// int
 	case *int:
 		value := cast.ToInt(v)
-		return &value
+		return &value, nil
 	case int:
-		return cast.ToInt(v)
+		return cast.ToInt(v), nil
 	// uint
 	case *uint:
 		value := cast.ToUint(v)
-		return &value
+		return &value, nil
 	case uint:
-		return cast.ToUint(v)
+		return cast.ToUint(v), nil
 	// int8
 	case *int8:
 		value := cast.ToInt8(v)
-		return &value
+		return &value, nil
 	case int8:
-		return cast.ToInt8(v)
+		return cast.ToInt8(v), nil
 	// unt8
 	case *uint8:
 		value := cast.ToUint8(v)
-		return &value
+		return &value, nil
 	case uint8:
-		return cast.ToUint8(v)
+		return cast.ToUint8(v), nil
 	// int16
 	case *int16:
 		value := cast.ToInt16(v)
-		return &value
+		return &value, nil
 	case int16:
-		return cast.ToInt16(v)
+		return cast.ToInt16(v), nil
 	// unit16
 	case *uint16:
 		value := cast.ToUint16(v)
-		return &value
+		return &value, nil
 	case uint16:
-		return cast.ToUint16(v)
+		return cast.ToUint16(v), nil
 	// int32
 	case *int32:
 		value := cast.ToInt32(v)
-		return &value
+		return &value, nil
 	case int32:
-		return cast.ToInt32(v)
+		return cast.ToInt32(v), nil
 	// uint32
 	case *uint32:
 		value := cast.ToUint32(v)
-		return &value
+		return &value, nil
 	case uint32:
-		return cast.ToUint32(v)
+		return cast.ToUint32(v), nil
 	// int64
 	case *int64:
 		value := cast.ToInt64(v)
-		return &value
+		return &value, nil
 	case int64:
-		return cast.ToInt64(v)
+		return cast.ToInt64(v), nil
 	// uint64
 	case *uint64:
 		value := cast.ToUint64(v)
-		return &value
+		return &value, nil
 	case uint64:
-		return cast.ToUint64(v)
+		return cast.ToUint64(v), nil
 	// float32
 	case *float32:
 		value := cast.ToFloat32(v)
-		return &value
+		return &value, nil
 	case float32:
-		return cast.ToFloat32(v)
+		return cast.ToFloat32(v), nil
 	// float64
 	case *float64:
 		value := cast.ToFloat64(v)
-		return &value
+		return &value, nil
 	case float64:
-		return cast.ToFloat64(v)
+		return cast.ToFloat64(v), nil
 	// time.Time
 	case *time.Time:
 		value := cast.ToTime(v)
-		return &value
+		return &value, nil
 	case time.Time:
-		return cast.ToTime(v)
+		return cast.ToTime(v), nil
 	// time.Duration
 	case *time.Duration:
 		value := cast.ToDuration(v)
-		return &value
+		return &value, nil
 	case time.Duration:
-		return cast.ToDuration(v)
+		return cast.ToDuration(v), nil
 	// rest
 	case []string:
-		return cast.ToStringSlice(v)
+		return cast.ToStringSlice(v), nil
 	case []int:
-		return cast.ToIntSlice(v)
+		return cast.ToIntSlice(v), nil
 	case map[string]string:
-		return cast.ToStringMapString(v)
+		return cast.ToStringMapString(v), nil
+	}
+	return v, nil
+}
+
+// checkAssignable reports whether v can be assigned to a field currently holding f
+// without going through spf13/cast's lenient conversions. It is only consulted when
+// WithWeaklyTypedInput(false) is in effect.
+func checkAssignable(f interface{}, v interface{}) error {
+	var target = reflect.TypeOf(f)
+	if target == nil {
+		// f is a nil interface value (e.g. an untyped interface{} field); nothing to
+		// check against, so let the caller's normal zeroing/casting handle it.
+		return nil
+	}
+	if target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+	var source = reflect.TypeOf(v)
+	if source == nil {
+		return nil
+	}
+	if source.Kind() == reflect.Ptr {
+		source = source.Elem()
+	}
+	if source == target || source.AssignableTo(target) {
+		return nil
 	}
-	return v
+	return fmt.Errorf("cannot assign value of type %s to field of type %s: WeaklyTypedInput is disabled", source, target)
 }
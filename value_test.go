@@ -0,0 +1,121 @@
+package konfig
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// newTestValue builds a *value suitable for exercising setStruct/assign/export directly,
+// without requiring the *S machinery (root config store, loaders) that lives outside
+// this package's chunk.
+func newTestValue() *value {
+	return &value{
+		mut:     &sync.Mutex{},
+		convMut: &sync.RWMutex{},
+		decOpts: newDecoderOptions(),
+	}
+}
+
+type sliceItem struct {
+	Name string `konfig:"name"`
+}
+
+type sliceHolder struct {
+	Tags  []string    `konfig:"tags"`
+	Items []sliceItem `konfig:"items"`
+}
+
+func TestSetStructSliceIndexScalar(t *testing.T) {
+	var val = newTestValue()
+	var target = reflect.New(reflect.TypeOf(sliceHolder{}))
+
+	// set out of order to exercise growth + preservation of existing indices
+	val.setStruct("tags.0", "a", target)
+	val.setStruct("tags.2", "c", target)
+	val.setStruct("tags.1", "b", target)
+
+	var got = target.Elem().Interface().(sliceHolder).Tags
+	var want = []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Tags = %#v, want %#v", got, want)
+	}
+}
+
+func TestSetStructSliceIndexStruct(t *testing.T) {
+	var val = newTestValue()
+	var target = reflect.New(reflect.TypeOf(sliceHolder{}))
+
+	val.setStruct("items.1.name", "second", target)
+	val.setStruct("items.0.name", "first", target)
+
+	var got = target.Elem().Interface().(sliceHolder).Items
+	if len(got) != 2 || got[0].Name != "first" || got[1].Name != "second" {
+		t.Fatalf("Items = %#v", got)
+	}
+}
+
+type celsius float64
+
+func TestAssignConverterDispatch(t *testing.T) {
+	var val = newTestValue()
+	val.registerConverter(
+		reflect.TypeOf(""),
+		reflect.TypeOf(celsius(0)),
+		func(v interface{}) (interface{}, error) {
+			return celsius(42), nil
+		},
+	)
+
+	type holder struct {
+		Temp celsius
+	}
+	var h holder
+	var field = reflect.ValueOf(&h).Elem().FieldByName("Temp")
+
+	val.assign(field, "temp", "anything")
+
+	if h.Temp != celsius(42) {
+		t.Fatalf("Temp = %v, want 42", h.Temp)
+	}
+}
+
+type withUnexported struct {
+	Port     int
+	internal string
+}
+
+func TestExportSkipsUnexportedFields(t *testing.T) {
+	var v = withUnexported{Port: 8080, internal: "secret"}
+	var out = make(map[string]interface{})
+
+	// must not panic: field.Interface() on the unexported "internal" field would
+	exportStruct(reflect.ValueOf(v), "", newDecoderOptions(), out)
+
+	if _, ok := out["internal"]; ok {
+		t.Fatalf("unexported field leaked into export: %#v", out)
+	}
+	if got := out["port"]; got != 8080 {
+		t.Fatalf("port = %v, want 8080", got)
+	}
+}
+
+func TestCheckAssignableNilInterface(t *testing.T) {
+	var f interface{}
+	if err := checkAssignable(f, "x"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+type withDefault struct {
+	Port int `konfig:"port,default=8080"`
+}
+
+func TestApplyDefaults(t *testing.T) {
+	var v withDefault
+	applyDefaults(reflect.ValueOf(&v).Elem(), newDecoderOptions())
+
+	if v.Port != 8080 {
+		t.Fatalf("Port = %d, want 8080", v.Port)
+	}
+}